@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Forge abstracts a single source of contribution data (a forge instance or
+// mailing list archive) so the rest of the program can aggregate across
+// several of them without caring how each one is queried.
+type Forge interface {
+	// FetchContributions returns the contributions made by user between
+	// since and until against whatever target this Forge was constructed
+	// for. Whether a contribution stamped exactly at since or until counts
+	// differs between implementations.
+	FetchContributions(ctx context.Context, user string, since, until time.Time) (Statistics, error)
+
+	// Name identifies this forge instance for display and aggregation,
+	// e.g. "github:owner/repo" or "gerrit:https://gerrit-review.googlesource.com".
+	Name() string
+}
+
+// NewForge parses a --forge spec of the form "<kind>:<target>" and returns
+// the Forge implementation for it.
+func NewForge(spec string, authToken string, includeCommits, debug bool, cacheDir, api string,
+	concurrency int, continueOnError bool) (Forge, error) {
+	kind, target, ok := strings.Cut(spec, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid forge spec %q, expected \"<kind>:<target>\"", spec)
+	}
+
+	switch kind {
+	case "github":
+		var repos []RepoRef
+		if target != "" {
+			for _, part := range strings.Split(target, ",") {
+				owner, name, ok := strings.Cut(part, "/")
+				if !ok {
+					return nil, fmt.Errorf("invalid github forge spec %q, expected \"github:owner/repo[,owner/repo...]\"", spec)
+				}
+				repos = append(repos, RepoRef{Owner: owner, Name: name})
+			}
+		} else if api != "graphql" {
+			return nil, fmt.Errorf("github forge spec %q requires owner/repo unless --api=graphql", spec)
+		}
+		return NewGitHubForge(repos, authToken, includeCommits, debug, cacheDir, api, concurrency, continueOnError)
+	case "gitlab":
+		group, project, ok := strings.Cut(target, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid gitlab forge spec %q, expected \"gitlab:group/project\"", spec)
+		}
+		return NewGitLabForge(group, project, authToken, debug), nil
+	case "gerrit":
+		return NewGerritForge(target, debug), nil
+	case "pipermail":
+		return NewPipermailForge(target, debug), nil
+	default:
+		return nil, fmt.Errorf("unknown forge kind %q in spec %q", kind, spec)
+	}
+}
+
+// aggregateStatistics sums the per-forge Statistics into a single totals
+// Statistics, keeping the individual breakdowns under ByForge so the HTML
+// template can render both a totals row and per-forge sections.
+func aggregateStatistics(byForge map[string]Statistics) Statistics {
+	var total Statistics
+	total.ByForge = make(map[string]Statistics, len(byForge))
+
+	for name, stats := range byForge {
+		total.PRsCount += stats.PRsCount
+		total.PRStats = append(total.PRStats, stats.PRStats...)
+		total.IssuesCount += stats.IssuesCount
+		total.IssueStats = append(total.IssueStats, stats.IssueStats...)
+		total.CommitsCount += stats.CommitsCount
+		total.CommitStats = append(total.CommitStats, stats.CommitStats...)
+		total.ByForge[name] = stats
+	}
+
+	return total
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// gerritMagicPrefix is the XSSI protection Gerrit prepends to every JSON
+// response so it can't be interpreted as a bare JSON array if loaded
+// directly as a <script>.
+const gerritMagicPrefix = ")]}'\n"
+
+// gerritTimestampLayout is the format Gerrit uses for all timestamps in its
+// REST API. It has no timezone and is always UTC.
+const gerritTimestampLayout = "2006-01-02 15:04:05.000000000"
+
+type gerritChange struct {
+	Subject string `json:"subject"`
+	Created string `json:"created"`
+	Number  int    `json:"_number"`
+	Owner   struct {
+		Username string `json:"username"`
+	} `json:"owner"`
+}
+
+// GerritForge queries a Gerrit instance's REST API for changes owned by a
+// contributor.
+type GerritForge struct {
+	host   string
+	debug  bool
+	client *http.Client
+}
+
+// NewGerritForge returns a Forge backed by the Gerrit REST API at host,
+// e.g. "https://gerrit-review.googlesource.com".
+func NewGerritForge(host string, debug bool) *GerritForge {
+	return &GerritForge{host: host, debug: debug, client: &http.Client{}}
+}
+
+func (g *GerritForge) Name() string {
+	return fmt.Sprintf("gerrit:%s", g.host)
+}
+
+func (g *GerritForge) FetchContributions(ctx context.Context, user string, since, until time.Time) (Statistics, error) {
+	query := fmt.Sprintf("owner:%s after:%s before:%s", user, since.Format("2006-01-02"), until.Format("2006-01-02"))
+	changesURL := g.host + "/changes/?" + url.Values{"q": {query}}.Encode()
+
+	if g.debug {
+		fmt.Printf("Gerrit HTTP Request URL: %s\n", changesURL)
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", changesURL, nil)
+	if err != nil {
+		return Statistics{}, err
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return Statistics{}, err
+	}
+	defer resp.Body.Close()
+
+	var changes []gerritChange
+	if err := decodeGerritResponse(resp, &changes); err != nil {
+		return Statistics{}, err
+	}
+
+	var filtered []Issue
+	for _, change := range changes {
+		createdAt, err := time.Parse(gerritTimestampLayout, change.Created)
+		if err != nil {
+			continue
+		}
+		if change.Owner.Username != user || createdAt.Before(since) || createdAt.After(until) {
+			continue
+		}
+		issue := Issue{
+			Title:     change.Subject,
+			URL:       fmt.Sprintf("%s/c/%d", g.host, change.Number),
+			CreatedAt: createdAt.Format(time.RFC3339),
+		}
+		issue.User.Login = change.Owner.Username
+		filtered = append(filtered, issue)
+	}
+
+	return Statistics{
+		IssuesCount: len(filtered),
+		IssueStats:  filtered,
+	}, nil
+}
+
+// decodeGerritResponse strips Gerrit's XSSI protection prefix before
+// unmarshalling, since the stdlib JSON decoder has no way to skip it.
+func decodeGerritResponse(resp *http.Response, target interface{}) error {
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("response returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	body = bytes.TrimPrefix(body, []byte(gerritMagicPrefix))
+
+	return json.Unmarshal(body, target)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDecodeGerritResponse(t *testing.T) {
+	body := gerritMagicPrefix + `[{"subject":"Fix thing","created":"2024-03-04 10:00:00.000000000","_number":42,"owner":{"username":"alice"}}]`
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+
+	var changes []gerritChange
+	if err := decodeGerritResponse(resp, &changes); err != nil {
+		t.Fatalf("decodeGerritResponse: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("got %d changes, want 1", len(changes))
+	}
+	if got, want := changes[0].Subject, "Fix thing"; got != want {
+		t.Errorf("Subject = %q, want %q", got, want)
+	}
+	if got, want := changes[0].Owner.Username, "alice"; got != want {
+		t.Errorf("Owner.Username = %q, want %q", got, want)
+	}
+}
+
+func TestDecodeGerritResponseWithoutMagicPrefix(t *testing.T) {
+	// Not every Gerrit-shaped endpoint necessarily adds the XSSI prefix;
+	// TrimPrefix is a no-op if it's absent, so plain JSON should still decode.
+	body := `[{"subject":"No prefix here","created":"2024-03-04 10:00:00.000000000","_number":7,"owner":{"username":"bob"}}]`
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+
+	var changes []gerritChange
+	if err := decodeGerritResponse(resp, &changes); err != nil {
+		t.Fatalf("decodeGerritResponse: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Subject != "No prefix here" {
+		t.Fatalf("got %+v, want a single decoded change", changes)
+	}
+}
+
+func TestDecodeGerritResponseErrorStatus(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+
+	var changes []gerritChange
+	if err := decodeGerritResponse(resp, &changes); err == nil {
+		t.Fatal("expected an error for a non-200 response, got nil")
+	}
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/fireyun/github-contribution-statistics/httpcache"
+)
+
+// httpDoer is satisfied by *httpcache.Client and *httpcache.RateLimitedClient,
+// so GitHubForge can be pointed at a cache directory or not while workers
+// fanning out across repos still share one rate-limit backoff.
+type httpDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// GitHubForge queries GitHub for the PRs, issues and (optionally) commits
+// authored by a contributor, either against one or more repositories via
+// the REST v3 API (fanned out concurrently when there's more than one) or
+// across all of a user's repositories via the GraphQL v4
+// ContributionsCollection query.
+type GitHubForge struct {
+	repos           []RepoRef
+	authToken       string
+	debug           bool
+	includeCommits  bool
+	api             string // "rest" or "graphql"
+	concurrency     int
+	continueOnError bool
+	client          httpDoer
+}
+
+// NewGitHubForge returns a Forge backed by GitHub. api selects "rest"
+// (the default, fanned out across repos) or "graphql" (scoped to the user
+// across all repositories; repos may be empty). Commits are only fetched
+// when includeCommits is set, since that request is the most expensive one
+// against the rate limit. When cacheDir is non-empty, responses are cached
+// on disk and revalidated with conditional GET instead of being re-fetched
+// on every run. concurrency bounds how many repos are queried at once;
+// continueOnError logs and skips a failing repo instead of aborting the
+// whole run.
+func NewGitHubForge(repos []RepoRef, authToken string, includeCommits, debug bool, cacheDir, api string,
+	concurrency int, continueOnError bool) (*GitHubForge, error) {
+	if api == "" {
+		api = "rest"
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	var client httpDoer
+	if cacheDir != "" {
+		cached, err := httpcache.New(cacheDir)
+		if err != nil {
+			return nil, err
+		}
+		client = cached
+	} else {
+		client = httpcache.NewRateLimited()
+	}
+	return &GitHubForge{
+		repos: repos, authToken: authToken, includeCommits: includeCommits, debug: debug, api: api,
+		concurrency: concurrency, continueOnError: continueOnError, client: client,
+	}, nil
+}
+
+func (g *GitHubForge) Name() string {
+	if len(g.repos) == 0 {
+		return "github:*"
+	}
+	if len(g.repos) == 1 {
+		return fmt.Sprintf("github:%s", g.repos[0])
+	}
+	return fmt.Sprintf("github:%d repos", len(g.repos))
+}
+
+func (g *GitHubForge) FetchContributions(ctx context.Context, user string, since, until time.Time) (Statistics, error) {
+	if g.api == "graphql" {
+		return g.fetchGraphQL(ctx, user, since, until)
+	}
+	return g.fetchRESTAll(ctx, user, since, until)
+}
+
+// fetchRESTAll fans the per-repo REST fetch out across a bounded worker
+// pool, merging results into Statistics.PerRepo plus totals (a single repo's
+// Statistics is returned as is, with PerRepo left nil). All workers
+// pause together on rate-limit backoff because they share one httpDoer.
+func (g *GitHubForge) fetchRESTAll(ctx context.Context, user string, since, until time.Time) (Statistics, error) {
+	var mu sync.Mutex
+	perRepo := make(map[string]Statistics, len(g.repos))
+
+	group, gctx := errgroup.WithContext(ctx)
+	group.SetLimit(g.concurrency)
+
+	for _, repo := range g.repos {
+		repo := repo
+		group.Go(func() error {
+			stats, err := g.fetchREST(gctx, repo, user, since, until)
+			if err != nil {
+				if g.continueOnError {
+					log.Printf("github forge: skipping %s after error: %v", repo, err)
+					return nil
+				}
+				return fmt.Errorf("%s: %w", repo, err)
+			}
+
+			mu.Lock()
+			perRepo[repo.String()] = stats
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return Statistics{}, err
+	}
+
+	if len(g.repos) == 1 {
+		return perRepo[g.repos[0].String()], nil
+	}
+	return mergeRepoStatistics(perRepo), nil
+}
+
+func (g *GitHubForge) fetchREST(ctx context.Context, repo RepoRef, user string, since, until time.Time) (Statistics, error) {
+	startDate := since.Format(time.RFC3339)
+	endDate := until.Format(time.RFC3339)
+	baseURL := fmt.Sprintf("https://api.github.com/repos/%s/%s", repo.Owner, repo.Name)
+
+	// Pull Requests
+	prsURL := fmt.Sprintf("%s/pulls?state=all&since=%s&until=%s&creator=%s&per_page=100",
+		baseURL, startDate, endDate, user)
+	if g.debug {
+		fmt.Printf("PR HTTP Request URL: %s\n", prsURL)
+	}
+	prsData, err := g.fetchAllPages(ctx, prsURL)
+	if err != nil {
+		return Statistics{}, err
+	}
+
+	var filteredPRs []PullRequest
+	for _, pr := range prsData {
+		if pr.User.Login == user && isWithinDateRange(pr.CreatedAt, startDate, endDate) {
+			filteredPRs = append(filteredPRs, pr)
+		}
+	}
+
+	// Issues
+	issuesURL := fmt.Sprintf("%s/issues?state=all&since=%s&until=%s&creator=%s&per_page=100",
+		baseURL, startDate, endDate, user)
+	if g.debug {
+		fmt.Printf("Issue HTTP Request URL: %s\n", issuesURL)
+	}
+	issuesData, err := g.fetchAllPages(ctx, issuesURL)
+	if err != nil {
+		return Statistics{}, err
+	}
+
+	var filteredIssues []Issue
+	for _, pr := range issuesData {
+		issue := Issue(pr)
+		if issue.User.Login == user && isWithinDateRange(issue.CreatedAt, startDate, endDate) {
+			filteredIssues = append(filteredIssues, issue)
+		}
+	}
+
+	statistics := Statistics{
+		PRsCount:    len(filteredPRs),
+		PRStats:     filteredPRs,
+		IssuesCount: len(filteredIssues),
+		IssueStats:  filteredIssues,
+	}
+
+	if g.includeCommits {
+		commitsData, err := g.fetchCommits(ctx, repo, user, startDate, endDate)
+		if err != nil {
+			return Statistics{}, err
+		}
+		statistics.CommitsCount = len(commitsData)
+		statistics.CommitStats = commitsData
+	}
+
+	return statistics, nil
+}
+
+func (g *GitHubForge) fetchCommits(ctx context.Context, repo RepoRef, user, startDate, endDate string) ([]Commit, error) {
+	commitsURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/commits?author=%s&since=%s&until=%s&per_page=100",
+		repo.Owner, repo.Name, user, startDate, endDate)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", commitsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if g.authToken != "" {
+		req.Header.Set("Authorization", "token "+g.authToken)
+	}
+
+	if g.debug {
+		fmt.Printf("Commit HTTP Request URL: %s\n", commitsURL)
+	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var commits []Commit
+	if err := decodeResponse(resp, &commits); err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
+
+// fetchAllPages follows the RFC 5988 Link header GitHub uses for pagination,
+// collecting PullRequest-shaped pages (also used to decode issues, whose
+// JSON shape is a superset we only read a subset of).
+func (g *GitHubForge) fetchAllPages(ctx context.Context, url string) ([]PullRequest, error) {
+	var allData []PullRequest
+
+	for url != "" {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		if g.authToken != "" {
+			req.Header.Set("Authorization", "token "+g.authToken)
+		}
+
+		resp, err := g.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		var data []PullRequest
+		if err := decodeResponse(resp, &data); err != nil {
+			return nil, err
+		}
+		allData = append(allData, data...)
+
+		url = extractNextPageURL(resp.Header.Get("Link"))
+		if g.debug {
+			fmt.Printf("next HTTP Request URL: %s\n", url)
+		}
+	}
+
+	return allData, nil
+}
+
+func extractNextPageURL(linkHeader string) string {
+	links := strings.Split(linkHeader, ",")
+	for _, link := range links {
+		components := strings.Split(strings.TrimSpace(link), ";")
+		if len(components) == 2 && strings.TrimSpace(components[1]) == `rel="next"` {
+			return strings.Trim(components[0], "<>")
+		}
+	}
+	return ""
+}
+
+// mergeRepoStatistics sums per-repo Statistics into totals, keeping the
+// individual breakdowns under PerRepo so the HTML template can render both
+// per-repo sections and a totals row.
+func mergeRepoStatistics(perRepo map[string]Statistics) Statistics {
+	var total Statistics
+	total.PerRepo = make(map[string]Statistics, len(perRepo))
+
+	for name, stats := range perRepo {
+		total.PRsCount += stats.PRsCount
+		total.PRStats = append(total.PRStats, stats.PRStats...)
+		total.IssuesCount += stats.IssuesCount
+		total.IssueStats = append(total.IssueStats, stats.IssueStats...)
+		total.CommitsCount += stats.CommitsCount
+		total.CommitStats = append(total.CommitStats, stats.CommitStats...)
+		total.PerRepo[name] = stats
+	}
+
+	return total
+}
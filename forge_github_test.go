@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestExtractNextPageURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{
+			name:   "next and last",
+			header: `<https://api.github.com/resource?page=2>; rel="next", <https://api.github.com/resource?page=5>; rel="last"`,
+			want:   "https://api.github.com/resource?page=2",
+		},
+		{
+			name:   "only last, no next",
+			header: `<https://api.github.com/resource?page=5>; rel="last"`,
+			want:   "",
+		},
+		{
+			name:   "empty header",
+			header: "",
+			want:   "",
+		},
+		{
+			name:   "next is not the first link",
+			header: `<https://api.github.com/resource?page=1>; rel="prev", <https://api.github.com/resource?page=3>; rel="next"`,
+			want:   "https://api.github.com/resource?page=3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractNextPageURL(tt.header); got != tt.want {
+				t.Errorf("extractNextPageURL(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// GitLabForge queries a single GitLab project for merge requests and issues
+// authored by a contributor via the GitLab REST v4 API.
+type GitLabForge struct {
+	group, project string
+	authToken      string
+	debug          bool
+	client         *http.Client
+}
+
+// NewGitLabForge returns a Forge backed by the GitLab REST v4 API for
+// group/project.
+func NewGitLabForge(group, project, authToken string, debug bool) *GitLabForge {
+	return &GitLabForge{group: group, project: project, authToken: authToken, debug: debug, client: &http.Client{}}
+}
+
+func (g *GitLabForge) Name() string {
+	return fmt.Sprintf("gitlab:%s/%s", g.group, g.project)
+}
+
+// gitlabItem is the shape shared by GitLab's merge_requests and issues
+// endpoints, which (unlike GitHub) link to the item via web_url and nest
+// the author under author.username rather than user.login.
+type gitlabItem struct {
+	Title     string `json:"title"`
+	WebURL    string `json:"web_url"`
+	CreatedAt string `json:"created_at"`
+	Author    struct {
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+func (g *GitLabForge) FetchContributions(ctx context.Context, user string, since, until time.Time) (Statistics, error) {
+	projectPath := fmt.Sprintf("%s/%s", g.group, g.project)
+	base := fmt.Sprintf("https://gitlab.com/api/v4/projects/%s", url.PathEscape(projectPath))
+
+	mrsData, err := g.fetchAllPages(ctx, fmt.Sprintf("%s/merge_requests?author_username=%s&created_after=%s&created_before=%s&per_page=100",
+		base, user, since.Format(time.RFC3339), until.Format(time.RFC3339)))
+	if err != nil {
+		return Statistics{}, err
+	}
+
+	issuesData, err := g.fetchAllPages(ctx, fmt.Sprintf("%s/issues?author_username=%s&created_after=%s&created_before=%s&per_page=100",
+		base, user, since.Format(time.RFC3339), until.Format(time.RFC3339)))
+	if err != nil {
+		return Statistics{}, err
+	}
+
+	prs := make([]PullRequest, len(mrsData))
+	for i, mr := range mrsData {
+		prs[i] = toPullRequest(mr)
+	}
+
+	issues := make([]Issue, len(issuesData))
+	for i, issue := range issuesData {
+		issues[i] = toIssue(issue)
+	}
+
+	return Statistics{
+		PRsCount:    len(prs),
+		PRStats:     prs,
+		IssuesCount: len(issues),
+		IssueStats:  issues,
+	}, nil
+}
+
+func toPullRequest(item gitlabItem) PullRequest {
+	pr := PullRequest{Title: item.Title, URL: item.WebURL, CreatedAt: item.CreatedAt}
+	pr.User.Login = item.Author.Username
+	return pr
+}
+
+func toIssue(item gitlabItem) Issue {
+	issue := Issue{Title: item.Title, URL: item.WebURL, CreatedAt: item.CreatedAt}
+	issue.User.Login = item.Author.Username
+	return issue
+}
+
+// fetchAllPages follows GitLab's X-Next-Page response header, which (unlike
+// GitHub) gives the next page number directly rather than a full Link URL.
+func (g *GitLabForge) fetchAllPages(ctx context.Context, url string) ([]gitlabItem, error) {
+	var allData []gitlabItem
+	page := 1
+
+	for page != 0 {
+		pagedURL := fmt.Sprintf("%s&page=%d", url, page)
+		req, err := http.NewRequestWithContext(ctx, "GET", pagedURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		if g.authToken != "" {
+			req.Header.Set("PRIVATE-TOKEN", g.authToken)
+		}
+
+		if g.debug {
+			fmt.Printf("GitLab HTTP Request URL: %s\n", pagedURL)
+		}
+		resp, err := g.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		var data []gitlabItem
+		if err := decodeResponse(resp, &data); err != nil {
+			return nil, err
+		}
+		allData = append(allData, data...)
+
+		page = 0
+		if next := resp.Header.Get("X-Next-Page"); next != "" {
+			page, err = strconv.Atoi(next)
+			if err != nil {
+				return nil, fmt.Errorf("parsing X-Next-Page %q: %w", next, err)
+			}
+		}
+	}
+
+	return allData, nil
+}
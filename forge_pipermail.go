@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// PipermailForge treats a Mailman/Pipermail mailing-list archive as a forge:
+// each message a contributor posted in the date range counts as one
+// contribution, surfaced alongside PRs and issues from other forges.
+type PipermailForge struct {
+	archiveURL string
+	debug      bool
+	client     *http.Client
+}
+
+// NewPipermailForge returns a Forge backed by the Pipermail mbox archives
+// under archiveURL, e.g. "https://lists.example.org/archives/foo/".
+func NewPipermailForge(archiveURL string, debug bool) *PipermailForge {
+	return &PipermailForge{archiveURL: strings.TrimSuffix(archiveURL, "/"), debug: debug, client: &http.Client{}}
+}
+
+func (p *PipermailForge) Name() string {
+	return fmt.Sprintf("pipermail:%s", p.archiveURL)
+}
+
+func (p *PipermailForge) FetchContributions(ctx context.Context, user string, since, until time.Time) (Statistics, error) {
+	var posts []Issue
+
+	for month := time.Date(since.Year(), since.Month(), 1, 0, 0, 0, 0, time.UTC); !month.After(until); month = month.AddDate(0, 1, 0) {
+		messages, err := p.fetchMonth(ctx, month)
+		if err != nil {
+			return Statistics{}, err
+		}
+
+		for _, msg := range messages {
+			if !strings.Contains(strings.ToLower(msg.from), strings.ToLower(user)) {
+				continue
+			}
+			if msg.date.Before(since) || msg.date.After(until) {
+				continue
+			}
+			post := Issue{
+				Title:     msg.subject,
+				URL:       fmt.Sprintf("%s/%s.html", p.archiveURL, month.Format("2006-January")),
+				CreatedAt: msg.date.Format(time.RFC3339),
+			}
+			post.User.Login = msg.from
+			posts = append(posts, post)
+		}
+	}
+
+	return Statistics{
+		IssuesCount: len(posts),
+		IssueStats:  posts,
+	}, nil
+}
+
+type pipermailMessage struct {
+	from    string
+	subject string
+	date    time.Time
+}
+
+// fetchMonth downloads and parses one monthly mbox archive, e.g.
+// ".../2024-March.txt.gz". A missing archive (the list had no traffic that
+// month) is not an error.
+func (p *PipermailForge) fetchMonth(ctx context.Context, month time.Time) ([]pipermailMessage, error) {
+	monthURL := fmt.Sprintf("%s/%s.txt.gz", p.archiveURL, month.Format("2006-January"))
+	if p.debug {
+		fmt.Printf("Pipermail HTTP Request URL: %s\n", monthURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", monthURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("response returned status %d", resp.StatusCode)
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	return parseMbox(gz)
+}
+
+// parseMbox extracts From/Date/Subject headers from each message in an mbox
+// stream, delimited by lines starting with "From " (the mbox envelope
+// separator, distinct from the From: header).
+func parseMbox(r interface{ Read([]byte) (int, error) }) ([]pipermailMessage, error) {
+	var messages []pipermailMessage
+	var current strings.Builder
+	flush := func() error {
+		if current.Len() == 0 {
+			return nil
+		}
+		msg, err := mail.ReadMessage(strings.NewReader(current.String()))
+		current.Reset()
+		if err != nil {
+			return nil // malformed message in the archive; skip it
+		}
+		date, err := mail.ParseDate(msg.Header.Get("Date"))
+		if err != nil {
+			return nil
+		}
+		messages = append(messages, pipermailMessage{
+			from:    msg.Header.Get("From"),
+			subject: msg.Header.Get("Subject"),
+			date:    date,
+		})
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "From ") {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		current.WriteString(line)
+		current.WriteByte('\n')
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return messages, scanner.Err()
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMbox(t *testing.T) {
+	const mbox = `From alice@example.org Mon Mar 04 10:00:00 2024
+From: Alice <alice@example.org>
+Subject: First post
+Date: Mon, 4 Mar 2024 10:00:00 +0000
+
+Hello list.
+
+From bob@example.org Tue Mar 05 11:00:00 2024
+From: Bob <bob@example.org>
+Subject: Re: First post
+Date: Tue, 5 Mar 2024 11:00:00 +0000
+
+Replying.
+`
+
+	messages, err := parseMbox(strings.NewReader(mbox))
+	if err != nil {
+		t.Fatalf("parseMbox: %v", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("got %d messages, want 2", len(messages))
+	}
+
+	if got, want := messages[0].from, "Alice <alice@example.org>"; got != want {
+		t.Errorf("messages[0].from = %q, want %q", got, want)
+	}
+	if got, want := messages[0].subject, "First post"; got != want {
+		t.Errorf("messages[0].subject = %q, want %q", got, want)
+	}
+	if got, want := messages[1].subject, "Re: First post"; got != want {
+		t.Errorf("messages[1].subject = %q, want %q", got, want)
+	}
+}
+
+func TestParseMboxSkipsMalformedMessages(t *testing.T) {
+	const mbox = `From broken@example.org Mon Mar 04 10:00:00 2024
+not a valid header block at all
+
+From alice@example.org Mon Mar 04 11:00:00 2024
+From: Alice <alice@example.org>
+Subject: Good message
+Date: Mon, 4 Mar 2024 11:00:00 +0000
+
+Body.
+`
+
+	messages, err := parseMbox(strings.NewReader(mbox))
+	if err != nil {
+		t.Fatalf("parseMbox: %v", err)
+	}
+	if len(messages) != 1 {
+		t.Fatalf("got %d messages, want 1 (malformed message should be skipped)", len(messages))
+	}
+	if got, want := messages[0].subject, "Good message"; got != want {
+		t.Errorf("messages[0].subject = %q, want %q", got, want)
+	}
+}
+
+func TestParseMboxEmpty(t *testing.T) {
+	messages, err := parseMbox(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("parseMbox: %v", err)
+	}
+	if len(messages) != 0 {
+		t.Fatalf("got %d messages, want 0", len(messages))
+	}
+}
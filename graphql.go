@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// graphQLQuery is the ContributionsCollection query used by
+// GitHubForge.fetchGraphQL. It replaces three separate paged REST calls
+// (commits, pulls, issues) filtered client-side with a single request,
+// and works across all of a user's repositories rather than just one.
+const graphQLQuery = `
+query($login: String!, $from: DateTime!, $to: DateTime!, $prCursor: String, $issueCursor: String) {
+  user(login: $login) {
+    contributionsCollection(from: $from, to: $to) {
+      totalCommitContributions
+      pullRequestContributions(first: 100, after: $prCursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          pullRequest {
+            title
+            url
+            createdAt
+            repository { nameWithOwner }
+          }
+        }
+      }
+      issueContributions(first: 100, after: $issueCursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          issue {
+            title
+            url
+            createdAt
+            repository { nameWithOwner }
+          }
+        }
+      }
+    }
+  }
+}`
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+type graphQLResponse struct {
+	Data struct {
+		User struct {
+			ContributionsCollection struct {
+				TotalCommitContributions int `json:"totalCommitContributions"`
+				PullRequestContributions struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						PullRequest struct {
+							Title      string `json:"title"`
+							URL        string `json:"url"`
+							CreatedAt  string `json:"createdAt"`
+							Repository struct {
+								NameWithOwner string `json:"nameWithOwner"`
+							} `json:"repository"`
+						} `json:"pullRequest"`
+					} `json:"nodes"`
+				} `json:"pullRequestContributions"`
+				IssueContributions struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						Issue struct {
+							Title      string `json:"title"`
+							URL        string `json:"url"`
+							CreatedAt  string `json:"createdAt"`
+							Repository struct {
+								NameWithOwner string `json:"nameWithOwner"`
+							} `json:"repository"`
+						} `json:"issue"`
+					} `json:"nodes"`
+				} `json:"issueContributions"`
+			} `json:"contributionsCollection"`
+		} `json:"user"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// fetchGraphQL queries GitHub's GraphQL v4 API for user's contributions
+// between since and until across all of their repositories, paging each
+// connection with its own cursor until exhausted.
+func (g *GitHubForge) fetchGraphQL(ctx context.Context, user string, since, until time.Time) (Statistics, error) {
+	var prs []PullRequest
+	var issues []Issue
+	var commitsCount int
+
+	prCursor, issueCursor := "", ""
+	prDone, issueDone := false, false
+	for !prDone || !issueDone {
+		resp, err := g.graphQLRequest(ctx, user, since, until, prCursor, issueCursor)
+		if err != nil {
+			return Statistics{}, err
+		}
+
+		commitsCount = resp.Data.User.ContributionsCollection.TotalCommitContributions
+
+		prConn := resp.Data.User.ContributionsCollection.PullRequestContributions
+		if !prDone {
+			for _, node := range prConn.Nodes {
+				pr := PullRequest{
+					Title:     node.PullRequest.Title,
+					URL:       node.PullRequest.URL,
+					CreatedAt: node.PullRequest.CreatedAt,
+				}
+				pr.User.Login = user
+				prs = append(prs, pr)
+			}
+			if prConn.PageInfo.HasNextPage {
+				prCursor = prConn.PageInfo.EndCursor
+			} else {
+				prDone = true
+			}
+		}
+
+		issueConn := resp.Data.User.ContributionsCollection.IssueContributions
+		if !issueDone {
+			for _, node := range issueConn.Nodes {
+				issue := Issue{
+					Title:     node.Issue.Title,
+					URL:       node.Issue.URL,
+					CreatedAt: node.Issue.CreatedAt,
+				}
+				issue.User.Login = user
+				issues = append(issues, issue)
+			}
+			if issueConn.PageInfo.HasNextPage {
+				issueCursor = issueConn.PageInfo.EndCursor
+			} else {
+				issueDone = true
+			}
+		}
+	}
+
+	statistics := Statistics{
+		PRsCount:    len(prs),
+		PRStats:     prs,
+		IssuesCount: len(issues),
+		IssueStats:  issues,
+	}
+	if g.includeCommits {
+		// The ContributionsCollection only exposes commit totals, not
+		// individual commits, so CommitStats stays empty in graphql mode.
+		statistics.CommitsCount = commitsCount
+	}
+
+	return statistics, nil
+}
+
+func (g *GitHubForge) graphQLRequest(ctx context.Context, user string, since, until time.Time, prCursor, issueCursor string) (*graphQLResponse, error) {
+	variables := map[string]interface{}{
+		"login": user,
+		"from":  since.Format(time.RFC3339),
+		"to":    until.Format(time.RFC3339),
+	}
+	if prCursor != "" {
+		variables["prCursor"] = prCursor
+	}
+	if issueCursor != "" {
+		variables["issueCursor"] = issueCursor
+	}
+
+	body, err := json.Marshal(graphQLRequest{Query: graphQLQuery, Variables: variables})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.github.com/graphql", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+g.authToken)
+	}
+
+	if g.debug {
+		fmt.Printf("GraphQL HTTP Request: %s\n", body)
+	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("graphql response returned status %d", resp.StatusCode)
+	}
+
+	var result graphQLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if len(result.Errors) > 0 {
+		return nil, fmt.Errorf("graphql error: %s", result.Errors[0].Message)
+	}
+
+	return &result, nil
+}
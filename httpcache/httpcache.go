@@ -0,0 +1,282 @@
+// Package httpcache provides an http.Client wrapper that persists responses
+// to disk and revalidates them with conditional GET, so repeated runs over
+// the same date window neither re-download unchanged data nor burn through
+// GitHub's rate limit.
+package httpcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimiter tracks GitHub's X-RateLimit-Remaining/X-RateLimit-Reset
+// headers across requests so callers sharing one rateLimiter (e.g.
+// concurrent workers fanning out over several repos) pause together
+// instead of each independently hitting a 403.
+type rateLimiter struct {
+	mu        sync.Mutex
+	remaining int
+	resetAt   time.Time
+}
+
+// waitForRateLimit sleeps until GitHub's reported reset time if the last
+// response said the quota is exhausted.
+func (rl *rateLimiter) waitForRateLimit() {
+	rl.mu.Lock()
+	remaining, resetAt := rl.remaining, rl.resetAt
+	rl.mu.Unlock()
+
+	if remaining == 0 && time.Now().Before(resetAt) {
+		time.Sleep(time.Until(resetAt))
+	}
+}
+
+func (rl *rateLimiter) record(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	resetUnix, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	rl.mu.Lock()
+	rl.remaining = remaining
+	rl.resetAt = time.Unix(resetUnix, 0)
+	rl.mu.Unlock()
+}
+
+// backoff reports how long to sleep before retrying resp's request, given
+// either an explicit Retry-After header or a 403 with the rate limit
+// reported exhausted (GitHub's "no Retry-After" throttling response).
+func (rl *rateLimiter) backoff(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusForbidden {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return d, true
+		}
+	}
+
+	if resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		resetUnix, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+		if err == nil {
+			if d := time.Until(time.Unix(resetUnix, 0)); d > 0 {
+				return d, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// RateLimitedClient wraps an *http.Client with shared rate-limit backoff
+// but no on-disk caching, for use when --cache-dir is not set.
+type RateLimitedClient struct {
+	HTTPClient *http.Client
+	limiter    rateLimiter
+}
+
+// NewRateLimited returns a RateLimitedClient that honors GitHub's rate-limit
+// headers without caching responses on disk.
+func NewRateLimited() *RateLimitedClient {
+	return &RateLimitedClient{HTTPClient: &http.Client{}, limiter: rateLimiter{remaining: -1}}
+}
+
+// Do sends req, pausing ahead of time if the rate limit is known to be
+// exhausted and, after the backoff, retrying for as long as the response
+// itself reports exhaustion.
+func (c *RateLimitedClient) Do(req *http.Request) (*http.Response, error) {
+	c.limiter.waitForRateLimit()
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	c.limiter.record(resp.Header)
+
+	if d, ok := c.limiter.backoff(resp); ok {
+		resp.Body.Close()
+		time.Sleep(d)
+		return c.Do(req)
+	}
+
+	return resp, nil
+}
+
+// Client wraps an *http.Client, caching GET responses under Dir and honoring
+// GitHub's rate-limit headers. The zero value is not usable; use New.
+type Client struct {
+	Dir        string
+	HTTPClient *http.Client
+
+	limiter rateLimiter
+}
+
+// New returns a Client that caches responses under dir, creating it if
+// necessary.
+func New(dir string) (*Client, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Client{Dir: dir, HTTPClient: &http.Client{}, limiter: rateLimiter{remaining: -1}}, nil
+}
+
+// entryMeta is the sidecar JSON stored next to each cached response body.
+type entryMeta struct {
+	Status       int         `json:"status"`
+	Header       http.Header `json:"header"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+}
+
+// Do sends req, serving and revalidating against the on-disk cache, and
+// blocking ahead of time if a prior response reported the rate limit is
+// exhausted.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	c.limiter.waitForRateLimit()
+
+	key := cacheKey(req)
+	meta, body, hit := c.load(key)
+
+	if hit {
+		if meta.ETag != "" {
+			req.Header.Set("If-None-Match", meta.ETag)
+		}
+		if meta.LastModified != "" {
+			req.Header.Set("If-Modified-Since", meta.LastModified)
+		}
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	c.limiter.record(resp.Header)
+
+	if d, ok := c.limiter.backoff(resp); ok {
+		resp.Body.Close()
+		time.Sleep(d)
+		return c.Do(req)
+	}
+
+	if resp.StatusCode == http.StatusNotModified && hit {
+		resp.Body.Close()
+		return c.cachedResponse(req, meta, body), nil
+	}
+
+	if resp.StatusCode == http.StatusOK && req.Method == http.MethodGet {
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		newMeta := entryMeta{
+			Status:       resp.StatusCode,
+			Header:       resp.Header,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		}
+		if err := c.store(key, newMeta, data); err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(strings.NewReader(string(data)))
+	}
+
+	return resp, nil
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+func (c *Client) cachedResponse(req *http.Request, meta entryMeta, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", meta.Status, http.StatusText(meta.Status)),
+		StatusCode:    meta.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        meta.Header,
+		Body:          io.NopCloser(strings.NewReader(string(body))),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+func (c *Client) load(key string) (entryMeta, []byte, bool) {
+	metaBytes, err := os.ReadFile(c.metaPath(key))
+	if err != nil {
+		return entryMeta{}, nil, false
+	}
+	var meta entryMeta
+	if err := json.Unmarshal(metaBytes, &meta); err != nil {
+		return entryMeta{}, nil, false
+	}
+	body, err := os.ReadFile(c.bodyPath(key))
+	if err != nil {
+		return entryMeta{}, nil, false
+	}
+	return meta, body, true
+}
+
+func (c *Client) store(key string, meta entryMeta, body []byte) error {
+	metaBytes, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.metaPath(key), metaBytes, 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(c.bodyPath(key), body, 0o644)
+}
+
+func (c *Client) metaPath(key string) string { return filepath.Join(c.Dir, key+".meta.json") }
+func (c *Client) bodyPath(key string) string { return filepath.Join(c.Dir, key+".body") }
+
+// cacheKey hashes method, URL, the bearer auth scope (not the token itself,
+// so cache files can't leak credentials) and sorted header names+values.
+func cacheKey(req *http.Request) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", req.Method, req.URL.String())
+
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		scope := auth
+		if i := strings.IndexByte(auth, ' '); i != -1 {
+			scope = auth[:i]
+		}
+		fmt.Fprintf(h, "auth-scope:%s\n", scope)
+	}
+
+	var names []string
+	for name := range req.Header {
+		if name == "Authorization" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(h, "%s:%s\n", name, strings.Join(req.Header.Values(name), ","))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
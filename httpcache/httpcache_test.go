@@ -0,0 +1,127 @@
+package httpcache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func mustRequest(t *testing.T, method, rawURL string) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(method, rawURL, nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	return req
+}
+
+func TestCacheKeyStableForEquivalentRequests(t *testing.T) {
+	req1 := mustRequest(t, "GET", "https://api.github.com/repos/owner/repo/issues")
+	req1.Header.Set("Accept", "application/json")
+	req1.Header.Set("X-Custom", "a")
+
+	req2 := mustRequest(t, "GET", "https://api.github.com/repos/owner/repo/issues")
+	req2.Header.Set("X-Custom", "a")
+	req2.Header.Set("Accept", "application/json")
+
+	if cacheKey(req1) != cacheKey(req2) {
+		t.Error("cacheKey should not depend on header insertion order")
+	}
+}
+
+func TestCacheKeyDiffersByMethodURLOrHeader(t *testing.T) {
+	base := mustRequest(t, "GET", "https://api.github.com/repos/owner/repo/issues")
+	baseKey := cacheKey(base)
+
+	otherMethod := mustRequest(t, "POST", "https://api.github.com/repos/owner/repo/issues")
+	if cacheKey(otherMethod) == baseKey {
+		t.Error("cacheKey should differ by method")
+	}
+
+	otherURL := mustRequest(t, "GET", "https://api.github.com/repos/owner/other/issues")
+	if cacheKey(otherURL) == baseKey {
+		t.Error("cacheKey should differ by URL")
+	}
+
+	otherHeader := mustRequest(t, "GET", "https://api.github.com/repos/owner/repo/issues")
+	otherHeader.Header.Set("Accept", "application/json")
+	if cacheKey(otherHeader) == baseKey {
+		t.Error("cacheKey should differ by header")
+	}
+}
+
+func TestCacheKeyIgnoresBearerTokenButNotScope(t *testing.T) {
+	tokenA := mustRequest(t, "GET", "https://api.github.com/repos/owner/repo/issues")
+	tokenA.Header.Set("Authorization", "token aaaaaaaa")
+
+	tokenB := mustRequest(t, "GET", "https://api.github.com/repos/owner/repo/issues")
+	tokenB.Header.Set("Authorization", "token bbbbbbbb")
+
+	if cacheKey(tokenA) != cacheKey(tokenB) {
+		t.Error("cacheKey should not depend on the bearer token itself, only its scope")
+	}
+
+	bearer := mustRequest(t, "GET", "https://api.github.com/repos/owner/repo/issues")
+	bearer.Header.Set("Authorization", "Bearer aaaaaaaa")
+	if cacheKey(tokenA) == cacheKey(bearer) {
+		t.Error("cacheKey should differ across auth scopes (token vs Bearer)")
+	}
+}
+
+func TestClientDoCachesAndRevalidatesWithETag(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"hello":"world"}`))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	client, err := New(dir)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	get := func() string {
+		req := mustRequest(t, "GET", srv.URL)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("ReadAll: %v", err)
+		}
+		return string(body)
+	}
+
+	first := get()
+	second := get()
+
+	if first != `{"hello":"world"}` || second != first {
+		t.Fatalf("got bodies %q and %q, want matching JSON payloads", first, second)
+	}
+	if requests != 2 {
+		t.Fatalf("server saw %d requests, want 2 (one fetch, one revalidation)", requests)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter(""); ok || d != 0 {
+		t.Errorf("parseRetryAfter(\"\") = %v, %v; want 0, false", d, ok)
+	}
+	if _, ok := parseRetryAfter("not-a-number"); ok {
+		t.Error("parseRetryAfter(\"not-a-number\") should fail to parse")
+	}
+	if d, ok := parseRetryAfter("30"); !ok || d.Seconds() != 30 {
+		t.Errorf("parseRetryAfter(\"30\") = %v, %v; want 30s, true", d, ok)
+	}
+}
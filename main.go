@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"embed"
 	"encoding/json"
 	"flag"
@@ -47,115 +48,16 @@ type Statistics struct {
 	IssueStats   []Issue       `json:"issue_stats"`
 	CommitsCount int           `json:"commits_count,omitempty"`
 	CommitStats  []Commit      `json:"commit_stats,omitempty"`
-}
-
-func getContributorStatistics(repoOwner, repoName, contributorUsername, startDate, endDate string,
-	includeCommits bool, authToken string, debug bool) (Statistics, error) {
-	baseURL := fmt.Sprintf("https://api.github.com/repos/%s/%s", repoOwner, repoName)
-
-	client := &http.Client{}
-
-	var commitsData []Commit
-	var commitsCount int
-
-	if includeCommits {
-		// Commits
-		commitsURL := fmt.Sprintf("%s/commits?author=%s&since=%s&until=%s&per_page=100",
-			baseURL, contributorUsername, startDate, endDate)
-
-		// Create the HTTP request
-		commitsReq, err := http.NewRequest("GET", commitsURL, nil)
-		if err != nil {
-			return Statistics{}, err
-		}
-
-		// Conditionally set the authentication token in the request header
-		if authToken != "" {
-			commitsReq.Header.Set("Authorization", "token "+authToken)
-		}
-
-		// Measure the time taken for the commits request
-		startTime := time.Now()
-		// Send the request
-		if debug {
-			fmt.Printf("Commit HTTP Request URL: %s\n", commitsURL)
-		}
-		commitsResp, err := client.Do(commitsReq)
-		elapsedTime := time.Since(startTime)
-		if err != nil {
-			return Statistics{}, err
-		}
-		defer commitsResp.Body.Close()
-
-		if err := decodeResponse(commitsResp, &commitsData); err != nil {
-			return Statistics{}, err
-		}
-		commitsCount = len(commitsData)
-		fmt.Printf("Commits request took %s\n", elapsedTime)
-	}
 
-	// Pull Requests
-	prsURL := fmt.Sprintf("%s/pulls?state=all&since=%s&until=%s&creator=%s&per_page=100",
-		baseURL, startDate, endDate, contributorUsername)
-	// Measure the time taken for the PRs request
-	startTime := time.Now()
-	if debug {
-		fmt.Printf("PR HTTP Request URL: %s\n", prsURL)
-	}
-	prsData, err := fetchAllPages(prsURL, authToken, debug)
-	elapsedTime := time.Since(startTime)
-	if err != nil {
-		return Statistics{}, err
-	}
+	// ByForge holds the per-forge breakdown when Statistics is the result
+	// of aggregateStatistics across multiple --forge specs. It is nil for
+	// a single forge's own Statistics.
+	ByForge map[string]Statistics `json:"by_forge,omitempty"`
 
-	// Filter PRs created by the contributor and within the desired date range
-	var filteredPRs []PullRequest
-	for _, pr := range prsData {
-		if pr.User.Login == contributorUsername && isWithinDateRange(pr.CreatedAt, startDate, endDate) {
-			filteredPRs = append(filteredPRs, pr)
-		}
-	}
-	prsCount := len(filteredPRs)
-	fmt.Printf("PRs request took %s\n", elapsedTime)
-
-	// Issues
-	issuesURL := fmt.Sprintf("%s/issues?state=all&since=%s&until=%s&creator=%s&per_page=100",
-		baseURL, startDate, endDate, contributorUsername)
-	// Measure the time taken for the issues request
-	startTime = time.Now()
-	if debug {
-		fmt.Printf("Issue HTTP Request URL: %s\n", prsURL)
-	}
-	issuesData, err := fetchAllPages(issuesURL, authToken, debug)
-	elapsedTime = time.Since(startTime)
-	if err != nil {
-		return Statistics{}, err
-	}
-
-	// Filter issues created by the contributor and within the desired date range
-	var filteredIssues []Issue
-	for _, issue := range issuesData {
-		if issue.User.Login == contributorUsername && isWithinDateRange(issue.CreatedAt, startDate, endDate) {
-			filteredIssues = append(filteredIssues, Issue(issue))
-		}
-	}
-	issuesCount := len(filteredIssues)
-	fmt.Printf("Issues request took %s\n", elapsedTime)
-
-	// Create the statistics
-	statistics := Statistics{
-		PRsCount:    prsCount,
-		PRStats:     filteredPRs,
-		IssuesCount: issuesCount,
-		IssueStats:  filteredIssues,
-	}
-
-	if includeCommits {
-		statistics.CommitsCount = commitsCount
-		statistics.CommitStats = commitsData
-	}
-
-	return statistics, nil
+	// PerRepo holds the per-repository breakdown when Statistics is the
+	// result of a GitHubForge fanning out over more than one repo. It is
+	// nil for a single-repo fetch.
+	PerRepo map[string]Statistics `json:"per_repo,omitempty"`
 }
 
 func isWithinDateRange(date, startDate, endDate string) bool {
@@ -175,61 +77,6 @@ func isWithinDateRange(date, startDate, endDate string) bool {
 	return parsedDate.After(parsedStartDate) && parsedDate.Before(parsedEndDate)
 }
 
-func fetchAllPages(url string, authToken string, debug bool) ([]PullRequest, error) {
-	var allData []PullRequest
-	client := &http.Client{}
-
-	for url != "" {
-		// Create the HTTP request
-		req, err := http.NewRequest("GET", url, nil)
-		if err != nil {
-			return nil, err
-		}
-
-		// Conditionally set the authentication token in the request header
-		if authToken != "" {
-			req.Header.Set("Authorization", "token "+authToken)
-		}
-
-		// Send the request
-		resp, err := client.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		defer resp.Body.Close()
-
-		var data []PullRequest
-		if err := decodeResponse(resp, &data); err != nil {
-			return nil, err
-		}
-
-		allData = append(allData, data...)
-
-		// Check if there is a next page
-		linkHeader := resp.Header.Get("Link")
-		nextURL := extractNextPageURL(linkHeader)
-		url = nextURL
-		if debug {
-			fmt.Printf("next HTTP Request URL: %s\n", url)
-		}
-		time.Sleep(time.Millisecond * 10)
-	}
-
-	return allData, nil
-}
-
-func extractNextPageURL(linkHeader string) string {
-	links := strings.Split(linkHeader, ",")
-	for _, link := range links {
-		components := strings.Split(strings.TrimSpace(link), ";")
-		if len(components) == 2 && strings.TrimSpace(components[1]) == `rel="next"` {
-			url := strings.Trim(components[0], "<>")
-			return url
-		}
-	}
-	return ""
-}
-
 func decodeResponse(resp *http.Response, target interface{}) error {
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("response returned status %d", resp.StatusCode)
@@ -276,19 +123,31 @@ func main() {
 	endDate := currentTime.Format("2006-01-02")
 
 	// Command line flags
-	repoOwner := flag.String("repoOwner", "TencentBlueKing", "Repository owner")
-	repoName := flag.String("repoName", "bk-bcs", "Repository name")
+	repoOwner := flag.String("repoOwner", "TencentBlueKing", "Repository owner (used to build a github forge spec when --forge is not given)")
+	repoName := flag.String("repoName", "bk-bcs", "Repository name (used to build a github forge spec when --forge is not given)")
 	contributorUsername := flag.String("contributorUsername", "fireyun", "Contributor username")
 	startDateFlag := flag.String("startDate", startDate, "Start date (format: YYYY-MM-DD)")
 	endDateFlag := flag.String("endDate", endDate, "End date (format: YYYY-MM-DD)")
 	filename := flag.String("filename", "statistics.html", "Output filename")
 	includeCommits := flag.Bool("includeCommits", false, "Include commit data in statistics")
-	authToken := flag.String("authToken", "", "GitHub authentication token")
+	authToken := flag.String("authToken", "", "Forge authentication token")
 	debug := flag.Bool("debug", true, "Enable debug mode to print HTTP request URLs")
+	cacheDir := flag.String("cache-dir", "", "Cache GitHub responses on disk under this directory and revalidate with conditional GET (disabled if empty)")
+	api := flag.String("api", "rest", "GitHub API to use: \"rest\" (per-repo) or \"graphql\" (cross-repo, via ContributionsCollection)")
+	mode := flag.String("mode", "stats", "Report mode: \"stats\" (contributor statistics) or \"stale\" (inactive repository report)")
+	reposFile := flag.String("repos-file", "", "File listing one \"owner/name\" repo per line (--mode=stale checks -repoOwner/-repoName when empty)")
+	staleYears := flag.Int("stale-years", 1, "Flag repos with no commits in this many years as stale (--mode=stale)")
+	staleMarkdown := flag.String("stale-markdown", "", "Also write a Markdown tracking-issue checklist of stale repos to this file (--mode=stale)")
+	concurrency := flag.Int("concurrency", 8, "Max concurrent per-repo fetches when querying more than one github repo")
+	continueOnError := flag.Bool("continue-on-error", false, "Log and skip a repo that fails instead of aborting the whole run")
+
+	var forgeSpecs forgeSpecFlag
+	flag.Var(&forgeSpecs, "forge", "Forge spec to query, may be repeated "+
+		"(e.g. github:owner/repo, gitlab:group/project, gerrit:https://gerrit-review.googlesource.com, "+
+		"pipermail:https://lists.example.org/archives/foo/)")
 
 	flag.Parse()
 
-	validateTime(*startDateFlag, *endDateFlag)
 	if *debug {
 		fmt.Println("Debug mode is enabled")
 		flag.VisitAll(func(f *flag.Flag) {
@@ -296,16 +155,109 @@ func main() {
 		})
 	}
 
-	statistics, err := getContributorStatistics(*repoOwner, *repoName, *contributorUsername, *startDateFlag,
-		*endDateFlag, *includeCommits, *authToken, *debug)
+	switch *mode {
+	case "stale":
+		runStaleMode(*reposFile, *repoOwner, *repoName, *staleYears, *authToken, *staleMarkdown, *filename, *debug)
+	case "stats":
+		runStatsMode(*repoOwner, *repoName, *reposFile, *contributorUsername, *startDateFlag, *endDateFlag, forgeSpecs,
+			*includeCommits, *authToken, *debug, *cacheDir, *api, *filename, *concurrency, *continueOnError)
+	default:
+		log.Fatalf("unknown --mode %q, expected \"stats\" or \"stale\"", *mode)
+	}
+}
+
+func runStatsMode(repoOwner, repoName, reposFile, contributorUsername, startDateFlag, endDateFlag string, forgeSpecs forgeSpecFlag,
+	includeCommits bool, authToken string, debug bool, cacheDir, api, filename string, concurrency int, continueOnError bool) {
+	validateTime(startDateFlag, endDateFlag)
+
+	if reposFile != "" {
+		refs, err := ParseRepoRefsFile(reposFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		parts := make([]string, len(refs))
+		for i, ref := range refs {
+			parts[i] = ref.String()
+		}
+		forgeSpecs = append(forgeSpecs, "github:"+strings.Join(parts, ","))
+	} else if len(forgeSpecs) == 0 {
+		if api == "graphql" {
+			forgeSpecs = append(forgeSpecs, "github:")
+		} else {
+			forgeSpecs = append(forgeSpecs, fmt.Sprintf("github:%s/%s", repoOwner, repoName))
+		}
+	}
+
+	since, err := time.Parse("2006-01-02", startDateFlag)
 	if err != nil {
 		log.Fatal(err)
 	}
+	until, err := time.Parse("2006-01-02", endDateFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	byForge := make(map[string]Statistics, len(forgeSpecs))
+	for _, spec := range forgeSpecs {
+		forge, err := NewForge(spec, authToken, includeCommits, debug, cacheDir, api, concurrency, continueOnError)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		stats, err := forge.FetchContributions(ctx, contributorUsername, since, until)
+		if err != nil {
+			log.Fatal(err)
+		}
+		byForge[forge.Name()] = stats
+	}
+
+	statistics := aggregateStatistics(byForge)
+
+	if err := generateHTML(statistics, filename); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Statistics generated successfully. Please check the file: %s\n", filename)
+}
 
-	err = generateHTML(statistics, *filename)
+func runStaleMode(reposFile, repoOwner, repoName string, staleYears int, authToken, staleMarkdown, filename string, debug bool) {
+	var refs []RepoRef
+	if reposFile != "" {
+		parsed, err := ParseRepoRefsFile(reposFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		refs = parsed
+	} else {
+		refs = []RepoRef{{Owner: repoOwner, Name: repoName}}
+	}
+
+	report, err := RunStaleReport(context.Background(), refs, staleYears, authToken, debug)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	fmt.Printf("Statistics generated successfully. Please check the file: %s\n", *filename)
+	if err := GenerateStaleHTML(report, filename); err != nil {
+		log.Fatal(err)
+	}
+	if staleMarkdown != "" {
+		if err := WriteStaleMarkdown(report, staleMarkdown); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	fmt.Printf("Stale repository report generated successfully. Please check the file: %s\n", filename)
+}
+
+// forgeSpecFlag collects repeated -forge flag values into a slice.
+type forgeSpecFlag []string
+
+func (f *forgeSpecFlag) String() string {
+	return fmt.Sprint([]string(*f))
+}
+
+func (f *forgeSpecFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
 }
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// RepoRef identifies a single GitHub repository to check or report on.
+type RepoRef struct {
+	Owner string
+	Name  string
+}
+
+func (r RepoRef) String() string {
+	return fmt.Sprintf("%s/%s", r.Owner, r.Name)
+}
+
+// ParseRepoRefsFile reads one "owner/name" per line from path, skipping
+// blank lines and "#"-prefixed comments, for use with --repos-file.
+func ParseRepoRefsFile(path string) ([]RepoRef, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var refs []RepoRef
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		owner, name, ok := strings.Cut(line, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid repo ref %q in %s, expected \"owner/name\"", line, path)
+		}
+		refs = append(refs, RepoRef{Owner: owner, Name: name})
+	}
+	return refs, scanner.Err()
+}
+
+// StaleRepoStatus records what we learned about one repository while
+// checking it for inactivity.
+type StaleRepoStatus struct {
+	Repo           RepoRef
+	LastCommitAt   time.Time
+	LastCommitURL  string
+	Archived       bool
+	Disabled       bool
+	NotFound       bool
+	RedirectedFrom string
+	RedirectedTo   string
+	Stale          bool
+}
+
+// StaleReport is the result of checking a list of repos for inactivity over
+// the last Threshold years.
+type StaleReport struct {
+	Threshold int // years
+	Repos     []StaleRepoStatus
+}
+
+type repoMetadata struct {
+	Archived bool `json:"archived"`
+	Disabled bool `json:"disabled"`
+}
+
+type repoCommit struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Author struct {
+			Date string `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+	HTMLURL string `json:"html_url"`
+}
+
+// CheckRepoStatus inspects a single repo's metadata and most recent commit,
+// following permanent redirects (a repo renamed or transferred) and
+// recording the repo's new location rather than treating that as failure.
+func CheckRepoStatus(ctx context.Context, client *http.Client, repo RepoRef, authToken string, debug bool) (StaleRepoStatus, error) {
+	status := StaleRepoStatus{Repo: repo}
+
+	metaURL := fmt.Sprintf("https://api.github.com/repos/%s/%s", repo.Owner, repo.Name)
+	if debug {
+		fmt.Printf("Stale metadata HTTP Request URL: %s\n", metaURL)
+	}
+	metaResp, err := doGitHubGet(ctx, client, metaURL, authToken)
+	if err != nil {
+		return status, err
+	}
+	defer metaResp.Body.Close()
+
+	if metaResp.StatusCode == http.StatusNotFound {
+		status.NotFound = true
+		return status, nil
+	}
+	if metaResp.Request != nil && metaResp.Request.URL.String() != metaURL {
+		status.RedirectedFrom = metaURL
+		status.RedirectedTo = metaResp.Request.URL.String()
+	}
+	var meta repoMetadata
+	if err := decodeResponse(metaResp, &meta); err != nil {
+		return status, err
+	}
+	status.Archived = meta.Archived
+	status.Disabled = meta.Disabled
+
+	commitsURL := fmt.Sprintf("%s/commits?per_page=1", metaURL)
+	if debug {
+		fmt.Printf("Stale commits HTTP Request URL: %s\n", commitsURL)
+	}
+	commitsResp, err := doGitHubGet(ctx, client, commitsURL, authToken)
+	if err != nil {
+		return status, err
+	}
+	defer commitsResp.Body.Close()
+
+	if commitsResp.StatusCode == http.StatusOK {
+		var commits []repoCommit
+		if err := decodeResponse(commitsResp, &commits); err != nil {
+			return status, err
+		}
+		if len(commits) > 0 {
+			status.LastCommitURL = commits[0].HTMLURL
+			if t, err := time.Parse(time.RFC3339, commits[0].Commit.Author.Date); err == nil {
+				status.LastCommitAt = t
+			}
+		}
+	}
+
+	return status, nil
+}
+
+// doGitHubGet performs a GET against the GitHub REST API, following
+// redirects via the default client behavior (net/http follows 301/302
+// automatically and reports the final URL on resp.Request).
+func doGitHubGet(ctx context.Context, client *http.Client, url, authToken string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if authToken != "" {
+		req.Header.Set("Authorization", "token "+authToken)
+	}
+	return client.Do(req)
+}
+
+// RunStaleReport checks every repo in refs and reports all of them, marking
+// as Stale those (other than not-found repos) with no commit activity in the
+// last thresholdYears years.
+func RunStaleReport(ctx context.Context, refs []RepoRef, thresholdYears int, authToken string, debug bool) (StaleReport, error) {
+	client := &http.Client{}
+	cutoff := time.Now().AddDate(-thresholdYears, 0, 0)
+
+	report := StaleReport{Threshold: thresholdYears}
+	for _, repo := range refs {
+		status, err := CheckRepoStatus(ctx, client, repo, authToken, debug)
+		if err != nil {
+			return StaleReport{}, fmt.Errorf("checking %s: %w", repo, err)
+		}
+		if !status.NotFound && status.LastCommitAt.Before(cutoff) {
+			status.Stale = true
+		}
+		report.Repos = append(report.Repos, status)
+	}
+
+	return report, nil
+}
+
+// GenerateStaleHTML renders report using templates/stale.html.
+func GenerateStaleHTML(report StaleReport, filename string) error {
+	tmpl, err := template.ParseFS(templates, "templates/stale.html")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return tmpl.Execute(f, report)
+}
+
+// WriteStaleMarkdown writes report as a Markdown checklist suitable for
+// pasting into a tracking issue, one checkbox per stale repo.
+func WriteStaleMarkdown(report StaleReport, filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fmt.Fprintf(f, "# Repos with no commits in the last %d year(s)\n\n", report.Threshold)
+	for _, status := range report.Repos {
+		if !status.Stale {
+			continue
+		}
+		label := status.Repo.String()
+		if status.Archived {
+			label += " (archived)"
+		}
+		if status.RedirectedTo != "" {
+			label += fmt.Sprintf(" (moved to %s)", status.RedirectedTo)
+		}
+		fmt.Fprintf(f, "- [ ] %s\n", label)
+	}
+
+	return nil
+}